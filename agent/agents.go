@@ -1,4 +1,4 @@
-package main
+package agent
 
 import "math/rand"
 
@@ -10,9 +10,9 @@ type Agent interface {
 	Alive() bool
         String() string
 	World() *World
-	AcceptScent(s *Scent)
 	AcceptPredator(p *Predator)
 	AcceptPrey(p *Prey)
+	AcceptBroadcast(b *Broadcast)
 }
 
 // A Generic is a generic agent with a home world and a location.
@@ -45,36 +45,53 @@ type NoPreyAction struct {
 func (a *NoPreyAction) AcceptPrey(p *Prey) {
 }
 
-// NoScentAction supplies a do-nothing AcceptScent function.
-type NoScentAction struct {
+// NoBroadcastAction supplies a do-nothing AcceptBroadcast function.
+type NoBroadcastAction struct {
 }
 
-func (a *NoScentAction) AcceptScent(s *Scent) {
+func (a *NoBroadcastAction) AcceptBroadcast(b *Broadcast) {
 }
 
-// NoActions supplies do-nothing functions for interations with predators, prey, and scents.
+// NoActions supplies do-nothing functions for interations with predators, prey,
+// and broadcasts.
 type NoActions struct {
      NoPredatorAction
      NoPreyAction
-     NoScentAction
+     NoBroadcastAction
 }
 
-// EatenByPredator supplies a AcceptPredator method that sets a bool to false.
+// EatenByPredator supplies an AcceptPredator method that sets a bool to false and
+// hands the predator that did the eating a fixed amount of energy. Only the first
+// Predator to call AcceptPredator in a given tick collects the energy; once alive
+// is cleared, every other Predator sharing the hex finds it already eaten.
 type EatenByPredator struct {
-	alive *bool
+	alive  *bool
+	energy float64
 }
 
 func (e *EatenByPredator) AcceptPredator(p *Predator) {
+	if !*(e.alive) {
+		return
+	}
 	*(e.alive) = false
+	p.energy += e.energy
 }
 
-// EatenByPredator supplies a AcceptPrey method that sets a bool to false.
+// EatenByPrey supplies an AcceptPrey method that sets a bool to false and hands the
+// prey that did the eating a fixed amount of energy. Only the first Prey to call
+// AcceptPrey in a given tick collects the energy; once alive is cleared, every other
+// Prey sharing the hex finds it already eaten.
 type EatenByPrey struct {
-	alive *bool
+	alive  *bool
+	energy float64
 }
 
 func (e *EatenByPrey) AcceptPrey(p *Prey) {
+	if !*(e.alive) {
+		return
+	}
 	*(e.alive) = false
+	p.energy += e.energy
 }
 
 // A Stationary is a Generic agent that also supplies a do-nothing Update function.
@@ -122,27 +139,78 @@ func (n *NonEmitter) Spawn() Agent {
 	return nil
 }
 
-// ScentFollower supplies an AcceptScent function that changes an externally allocated
-// Hex to the origin of the Scent.
+// ScentFollower supplies climbing behavior for agents that chase a pheromone
+// trail: each tick it samples the pheromone field around the follower's
+// current hex and aims its externally allocated goal at whichever neighbor
+// holds the steepest gradient, so the embedding Mobile steps toward it one
+// hex at a time.
 type ScentFollower struct {
      goal *Hex
 }
 
-// AcceptScent changes the external goal to the origin of the Scent.
-func (a *ScentFollower) AcceptScent(s *Scent) {
-	*(a.goal) = s.Origin()
+// Climb aims the goal at the neighboring hex with the highest pheromone level, if
+// one is richer than here. Otherwise it leaves the goal untouched, so a follower
+// standing on a flat or empty patch keeps heading for its last wander goal instead
+// of being locked in place by Gradient returning here every tick.
+func (a *ScentFollower) Climb(w *World, here Hex) {
+	if next := w.Gradient(here); next != here {
+		*(a.goal) = next
+	}
 }
 
-// A Predator is a mobile, immortal agent that spawns nothing, doesn't react when acted on
-// by other predators or preys, follows scents, and chooses a random location in the world
-// as its new goal when it reaches its current goal.
+// PredatorParams configures the energy, aging, reproduction, and broadcasting
+// behavior of a Predator.
+type PredatorParams struct {
+	InitialEnergy      float64
+	EnergyCost         float64
+	EnergyFromPrey     float64
+	MaxLifespan        int
+	ReproductiveAge    int
+	ReproductionCost   float64
+	ReproductionChance float64
+
+	// BroadcastThreshold is the pheromone level a Predator's hex must reach before
+	// it calls in other Predators to converge on it.
+	BroadcastThreshold float64
+	// BroadcastSpeed is how fast, in hexes per tick, a Predator's broadcast expands.
+	BroadcastSpeed float64
+}
+
+// DefaultPredatorParams holds the lifecycle parameters used by NewPredator.
+var DefaultPredatorParams = PredatorParams{
+	InitialEnergy:      30,
+	EnergyCost:         1,
+	EnergyFromPrey:     15,
+	MaxLifespan:        300,
+	ReproductiveAge:    20,
+	ReproductionCost:   15,
+	ReproductionChance: 0.05,
+	BroadcastThreshold: 5,
+	BroadcastSpeed:     2,
+}
+
+// A Predator is a mobile, mortal agent that climbs the pheromone field toward its
+// richest neighbor each tick, chooses a random location in the world as its new goal
+// when it reaches it with no gradient to follow, ages and spends energy every tick,
+// asexually spawns a child once it is old and well-fed enough, converges on other
+// Predators' broadcasts, and calls in other Predators of its own once it finds a
+// pheromone-rich hex.
 type Predator struct {
 	Mobile
-	Immortal
-	NonEmitter
+	Emitter
+	Mortal
 	NoPredatorAction
 	NoPreyAction
 	ScentFollower
+
+	params PredatorParams
+	energy float64
+	age    int
+
+	// broadcasted tracks whether this visit to a pheromone-rich hex has already
+	// called in other Predators, so Spawn sends one Broadcast per rich-hex visit
+	// rather than one every tick the Predator lingers there.
+	broadcasted bool
 }
 
 // String returns "P" as the printable representation of a Predator.
@@ -150,33 +218,104 @@ func (p *Predator) String() string {
 	return "P"
 }
 
-// NewPredator creates and returns a new predator at a random location in the world
-// with a randomly selected goal.
+// Update climbs the pheromone gradient around the Predator's current hex, takes its
+// usual step toward its goal, and pays the tick's energy cost as it ages.
+func (p *Predator) Update() {
+	p.ScentFollower.Climb(p.World(), p.Hex)
+	p.Mobile.Update()
+	p.energy -= p.params.EnergyCost
+	p.age++
+}
+
+// AcceptBroadcast points the Predator's goal at the Broadcast's origin, so a call
+// for help from another Predator draws it toward the rich hunting ground.
+func (p *Predator) AcceptBroadcast(b *Broadcast) {
+	*(p.ScentFollower.goal) = b.Origin()
+}
+
+// Spawn calls in other Predators with a Broadcast the first tick the Predator finds
+// itself on a pheromone-rich hex, defers to its reproduction Emitter for as long as
+// it lingers there after that, and re-arms once it leaves. Only one Broadcast or
+// reproduction can happen in a given tick.
+func (p *Predator) Spawn() Agent {
+	if p.World().Level(p.Hex) < p.params.BroadcastThreshold {
+		p.broadcasted = false
+		return p.Emitter.Spawn()
+	}
+	if p.broadcasted {
+		return p.Emitter.Spawn()
+	}
+	p.broadcasted = true
+	return NewBroadcast(p, p.params.BroadcastSpeed)
+}
+
+// NewPredator creates and returns a new predator at a random location in the world,
+// governed by DefaultPredatorParams.
 func NewPredator(w *World) *Predator {
+	return NewPredatorWithParams(w, DefaultPredatorParams)
+}
+
+// NewPredatorWithParams creates and returns a new predator at a random location in
+// the world, governed by the given PredatorParams.
+func NewPredatorWithParams(w *World, params PredatorParams) *Predator {
 	goal := w.Random()
+	alive := true
+
 	p := &Predator{
 		Mobile{
 			NewGeneric(w),
 			&goal,
 			func () { goal.Copy(w.Random()) }} ,
-		Immortal{},
-		NonEmitter{},
+		Emitter{rate: 1, rng: w.rng},
+		Mortal{alive: &alive, energy: new(float64), age: new(int), maxLife: params.MaxLifespan},
 		NoPredatorAction{},
 		NoPreyAction{},
-		ScentFollower{&goal} }
-	
+		ScentFollower{&goal},
+		params,
+		params.InitialEnergy,
+		0,
+		false }
+
+	p.Mortal.energy = &p.energy
+	p.Mortal.age = &p.age
+	p.Condition = func() bool {
+		return p.age >= p.params.ReproductiveAge &&
+			p.energy > p.params.ReproductionCost &&
+			w.rng.Float64() < p.params.ReproductionChance
+	}
+	p.Emit = func() Agent {
+		p.energy -= p.params.ReproductionCost
+		return NewPredatorWithParams(w, p.params)
+	}
+
 	return p
 }
 
-// Mortal supplies an Alive function that returns the value of an externally allocated
-// boolean.
+// Mortal supplies an Alive function that returns false once an externally allocated
+// alive flag has been cleared, or once an externally allocated energy total has been
+// depleted, or once an externally allocated age has reached a maxLife.  Agents that
+// don't track energy or age (Food) leave those fields nil and a maxLife of 0, which
+// never trip.
 type Mortal struct {
-	alive *bool
+	alive   *bool
+	energy  *float64
+	age     *int
+	maxLife int
 }
 
-// Alive returns the value of the external alive field.
+// Alive returns false once the alive flag is cleared, energy is depleted, or age has
+// reached maxLife.
 func (m *Mortal) Alive() bool {
-	return *(m.alive)
+	if !*(m.alive) {
+		return false
+	}
+	if m.energy != nil && *(m.energy) <= 0 {
+		return false
+	}
+	if m.maxLife > 0 && *(m.age) >= m.maxLife {
+		return false
+	}
+	return true
 }
 
 // An Origined is something that has a starting location.
@@ -193,67 +332,47 @@ func (e *Emitted) Origin() Hex {
 	return e.origin
 }
 
-// A Scent is an Origined, Mobile, Mortal Agent that is spawned by an Agent.  It dies when
-// it reaches the goal it randomly selects upon creation.
-type Scent struct {
-	Mobile
-	NonEmitter
-	Mortal
-	Emitted
-	NoActions
-}
-
-// String returns "x" as the printable representation of a Scent.
-func (s *Scent) String() string {
-	return "x"
-}
-
-// NewScent returns a new Scent that starts at the location of the given Agent.
-func NewScent(a Agent) *Scent {
-	r, c := a.Position()
-	origin := Hex{r, c}
-	goal := a.World().RandomBorder()
-
-	alive := true
-
-	return &Scent{
-		Mobile{
-			Generic{a.World(), Hex{r, c}},
-			&goal,
-			func () { alive = false } },
-		NonEmitter{},
-		Mortal{&alive},
-	        Emitted{origin},
-		NoActions{} }
-}
-
 // Emitter supplies a Spawn function that spawns a new Agent at the rate given by the
-// rate field.  The agent to be spawned is determined by the Emit function that embedding
-// objects must supply.
+// rate field, as long as an optional Condition also passes.  The agent to be spawned is
+// determined by the Emit function that embedding objects must supply.  Condition may be
+// left nil, in which case the rate roll alone decides whether to spawn.  rng is drawn
+// from the owning World so that runs seeded through World construction stay
+// reproducible.
 type Emitter struct {
-	rate int
-	Emit func () Agent
+	rate      int
+	rng       *rand.Rand
+	Condition func() bool
+	Emit      func() Agent
 }
 
 // Spawn spawns an Agent at an average rate of once per whatever value is held in the rate
-// field.  The Agent to spawn is determined by the Emit function.
+// field, provided Condition is nil or returns true.  The Agent to spawn is determined by
+// the Emit function.
 func (e *Emitter) Spawn() Agent {
-	if rand.Intn(e.rate) == 0 {
-		return e.Emit()
-	} else {
+	if e.rng.Intn(e.rate) != 0 {
+		return nil
+	}
+	if e.Condition != nil && !e.Condition() {
 		return nil
 	}
+	return e.Emit()
 }
 
-// Food is a Stationary, Mortal Agent that emits Scents on average once per 5 time steps
-// and dies when it is eaten by a Prey.
+// FoodPheromoneRate is the amount of pheromone Food deposits into the world each tick.
+const FoodPheromoneRate = 1.0
+
+// FoodEnergyValue is the amount of energy a Prey gains from eating a Food.
+const FoodEnergyValue = 10
+
+// Food is a Stationary, Mortal Agent that deposits pheromone into the world every
+// tick and dies when it is eaten by a Prey.
 type Food struct {
 	Stationary
-	Emitter
+	NonEmitter
 	Mortal
 	NoPredatorAction
 	EatenByPrey
-	NoScentAction
+	NoBroadcastAction
 }
 
 // String returns "*" as the printable representation of Food.
@@ -261,8 +380,21 @@ func (f *Food) String() string {
 	return "*"
 }
 
-// NewFood returns a new Food at a randomly chosen location in the world.
+// Update deposits pheromone at the Food's hex. Food doesn't move, so this replaces
+// Stationary's do-nothing Update.
+func (f *Food) Update() {
+	f.World().Deposit(f.Hex, FoodPheromoneRate)
+}
+
+// NewFood returns a new Food at a randomly chosen location in the world, worth
+// FoodEnergyValue to the Prey that eats it.
 func NewFood(w *World) *Food {
+	return NewFoodWithEnergy(w, FoodEnergyValue)
+}
+
+// NewFoodWithEnergy returns a new Food at a randomly chosen location in the world,
+// worth the given amount of energy to the Prey that eats it.
+func NewFoodWithEnergy(w *World, energyFromFood float64) *Food {
 	alive := true
 
 	f := Food{
@@ -270,27 +402,59 @@ func NewFood(w *World) *Food {
 			Generic{
 				w,
 				w.Random()} },
-		Emitter{
-			5,
-			nil },
-		Mortal{&alive},
+		NonEmitter{},
+		Mortal{alive: &alive},
 		NoPredatorAction{},
-		EatenByPrey{&alive},
-		NoScentAction{} }
-	f.Emit = func () Agent { return NewScent(&f) }
+		EatenByPrey{alive: &alive, energy: energyFromFood},
+		NoBroadcastAction{} }
 
 	return &f
 }
 
-// A Prey is a Mobile, Mortal agent that emits nothing, dies when eaten by a Predator,
-// and follows Scents.
+// PreyPheromoneRate is the amount of pheromone a Prey deposits into the world each tick.
+const PreyPheromoneRate = 0.5
+
+// PreyEnergyValue is the amount of energy a Predator gains from eating a Prey.
+const PreyEnergyValue = 15
+
+// PreyParams configures the energy, aging, and reproduction lifecycle of a Prey.
+type PreyParams struct {
+	InitialEnergy      float64
+	EnergyCost         float64
+	EnergyFromFood     float64
+	MaxLifespan        int
+	ReproductiveAge    int
+	ReproductionCost   float64
+	ReproductionChance float64
+}
+
+// DefaultPreyParams holds the lifecycle parameters used by NewPrey.
+var DefaultPreyParams = PreyParams{
+	InitialEnergy:      20,
+	EnergyCost:         1,
+	EnergyFromFood:     FoodEnergyValue,
+	MaxLifespan:        200,
+	ReproductiveAge:    10,
+	ReproductionCost:   10,
+	ReproductionChance: 0.1,
+}
+
+// A Prey is a Mobile, mortal agent that dies when eaten by a Predator, climbs the
+// pheromone field toward its richest neighbor each tick, deposits its own trail as it
+// goes, ages and spends energy every tick, and asexually spawns a child once it is old
+// and well-fed enough.
 type Prey struct {
 	Mobile
-	NonEmitter
+	Emitter
 	Mortal
 	EatenByPredator
 	NoPreyAction
+	NoBroadcastAction
 	ScentFollower
+
+	params PreyParams
+	energy float64
+	age    int
 }
 
 // String returns "p" as the printable representation of a Prey.
@@ -298,21 +462,57 @@ func (p *Prey) String() string {
 	return "p"
 }
 
-// NewPrey creates and returns a new Prey at a randomly chosen location and with a randomly
-// chosen goal.
+// Update climbs the pheromone gradient around the Prey's current hex, takes its usual
+// step toward its goal, deposits pheromone at its new location, and pays the tick's
+// energy cost as it ages.
+func (p *Prey) Update() {
+	p.ScentFollower.Climb(p.World(), p.Hex)
+	p.Mobile.Update()
+	p.World().Deposit(p.Hex, PreyPheromoneRate)
+	p.energy -= p.params.EnergyCost
+	p.age++
+}
+
+// NewPrey creates and returns a new Prey at a randomly chosen location and with a
+// randomly chosen goal, governed by DefaultPreyParams, worth PreyEnergyValue to the
+// Predator that eats it.
 func NewPrey(w *World) *Prey {
+	return NewPreyWithParams(w, DefaultPreyParams, PreyEnergyValue)
+}
+
+// NewPreyWithParams creates and returns a new Prey at a randomly chosen location and
+// with a randomly chosen goal, governed by the given PreyParams, worth
+// energyFromPrey to the Predator that eats it.
+func NewPreyWithParams(w *World, params PreyParams, energyFromPrey float64) *Prey {
 	goal := w.Random()
 	alive := true
+
 	p := &Prey{
 		Mobile{
 			NewGeneric(w),
 			&goal,
 			func () { goal.Copy(w.Random()) }} ,
-		NonEmitter{},
-	        Mortal{&alive},
-		EatenByPredator{&alive},
+		Emitter{rate: 1, rng: w.rng},
+	        Mortal{alive: &alive, energy: new(float64), age: new(int), maxLife: params.MaxLifespan},
+		EatenByPredator{alive: &alive, energy: energyFromPrey},
 		NoPreyAction{},
-		ScentFollower{&goal} }
+		NoBroadcastAction{},
+		ScentFollower{&goal},
+		params,
+		params.InitialEnergy,
+		0 }
+
+	p.Mortal.energy = &p.energy
+	p.Mortal.age = &p.age
+	p.Condition = func() bool {
+		return p.age >= p.params.ReproductiveAge &&
+			p.energy > p.params.ReproductionCost &&
+			w.rng.Float64() < p.params.ReproductionChance
+	}
+	p.Emit = func() Agent {
+		p.energy -= p.params.ReproductionCost
+		return NewPreyWithParams(w, p.params, p.EatenByPredator.energy)
+	}
 
 	return p
 }
@@ -0,0 +1,54 @@
+package agent
+
+// A Broadcast is an Origined, stationary, non-reproducing Agent spawned by a
+// Predator that has found a pheromone-rich hex. It propagates outward from its
+// origin at a finite speed, delivering itself to every Predator it reaches via
+// AcceptBroadcast so they can converge on the hunting ground, and dies once it
+// has covered the whole World.
+type Broadcast struct {
+	Generic
+	NonEmitter
+	Emitted
+	NoActions
+
+	speed     float64
+	reach     float64
+	remaining []HexDist
+}
+
+// String returns "~" as the printable representation of a Broadcast.
+func (b *Broadcast) String() string {
+	return "~"
+}
+
+// NewBroadcast returns a new Broadcast centered on the given Agent's hex, expanding
+// outward at the given speed in hexes per tick.
+func NewBroadcast(a Agent, speed float64) *Broadcast {
+	r, c := a.Position()
+	origin := Hex{r, c}
+
+	return &Broadcast{
+		Generic:   Generic{a.World(), origin},
+		Emitted:   Emitted{origin},
+		remaining: a.World().Neighborhood(origin),
+		speed:     speed,
+	}
+}
+
+// Update grows the Broadcast's reach by its speed and delivers the Broadcast to
+// every Agent standing on a hex newly brought within reach.
+func (b *Broadcast) Update() {
+	b.reach += b.speed
+	for len(b.remaining) > 0 && float64(b.remaining[0].Dist) <= b.reach {
+		hex := b.remaining[0].Hex
+		b.remaining = b.remaining[1:]
+		for _, occupant := range b.World().At(hex) {
+			occupant.AcceptBroadcast(b)
+		}
+	}
+}
+
+// Alive reports whether the Broadcast still has hexes left to reach.
+func (b *Broadcast) Alive() bool {
+	return len(b.remaining) > 0
+}
@@ -0,0 +1,86 @@
+package agent
+
+import "math/rand"
+
+// A Positioned is anything with a location that can be queried and stepped closer to
+// a goal hex.
+type Positioned interface {
+	Position() (int, int)
+	Closer(Hex)
+}
+
+// A Hex is a location in the world expressed in axial hex coordinates.
+type Hex struct {
+	R, C int
+}
+
+// Position returns the row and column of a Hex.
+func (h Hex) Position() (int, int) {
+	return h.R, h.C
+}
+
+// Copy sets a Hex to the same location as another Hex.
+func (h *Hex) Copy(o Hex) {
+	h.R = o.R
+	h.C = o.C
+}
+
+// Distance returns the hex grid distance between two Hexes.
+func (h Hex) Distance(o Hex) int {
+	dr := h.R - o.R
+	dc := h.C - o.C
+	ds := (h.R + h.C) - (o.R + o.C)
+	return (abs(dr) + abs(dc) + abs(ds)) / 2
+}
+
+// Closer moves a Hex one step along the grid toward a goal Hex.
+func (h *Hex) Closer(goal Hex) {
+	if *h == goal {
+		return
+	}
+	best := *h
+	bestDist := h.Distance(goal)
+	for _, n := range h.Neighbors() {
+		if d := n.Distance(goal); d < bestDist {
+			best = n
+			bestDist = d
+		}
+	}
+	*h = best
+}
+
+// Neighbors returns the six Hexes adjacent to h.
+func (h Hex) Neighbors() []Hex {
+	dirs := [6][2]int{{1, 0}, {1, -1}, {0, -1}, {-1, 0}, {-1, 1}, {0, 1}}
+	ns := make([]Hex, 6)
+	for i, d := range dirs {
+		ns[i] = Hex{h.R + d[0], h.C + d[1]}
+	}
+	return ns
+}
+
+// Cartesian returns the pixel-space x, y coordinates of a Hex, useful for rendering.
+func (h Hex) Cartesian() (float64, float64) {
+	x := float64(h.C) + float64(h.R)/2
+	y := float64(h.R) * 0.75
+	return x, y
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// randomHex returns a uniformly random Hex within the given radius of the origin.
+func randomHex(rng *rand.Rand, radius int) Hex {
+	for {
+		r := rng.Intn(2*radius+1) - radius
+		c := rng.Intn(2*radius+1) - radius
+		h := Hex{r, c}
+		if h.Distance(Hex{0, 0}) <= radius {
+			return h
+		}
+	}
+}
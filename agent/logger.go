@@ -0,0 +1,52 @@
+package agent
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+)
+
+// A PopulationLogger writes per-tick population counts to a CSV file, for later
+// plotting of predator, prey, and food counts over the course of a run.
+type PopulationLogger struct {
+	file   *os.File
+	writer *csv.Writer
+}
+
+// NewPopulationLogger creates a PopulationLogger that writes to the file at path,
+// truncating it if it already exists, and writes the CSV header row.
+func NewPopulationLogger(path string) (*PopulationLogger, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"tick", "predators", "prey", "food"}); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &PopulationLogger{f, w}, nil
+}
+
+// Log writes a row recording the World's population counts at the given tick.
+func (l *PopulationLogger) Log(tick int, w *World) error {
+	predators, prey, food := w.Population()
+	return l.writer.Write([]string{
+		fmt.Sprint(tick),
+		fmt.Sprint(predators),
+		fmt.Sprint(prey),
+		fmt.Sprint(food),
+	})
+}
+
+// Close flushes any buffered rows and closes the underlying file.
+func (l *PopulationLogger) Close() error {
+	l.writer.Flush()
+	if err := l.writer.Error(); err != nil {
+		l.file.Close()
+		return err
+	}
+	return l.file.Close()
+}
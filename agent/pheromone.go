@@ -0,0 +1,58 @@
+package agent
+
+// Deposit adds the given amount of pheromone to the field at h, for agents
+// (Food, Prey) to lay down a trail as they move or feed.
+func (w *World) Deposit(h Hex, amount float64) {
+	w.pheromone[h] += amount
+}
+
+// Level returns the current pheromone level at h.
+func (w *World) Level(h Hex) float64 {
+	return w.pheromone[h]
+}
+
+// DiffuseDecay spreads each hex's pheromone level to its six neighbors at the
+// given diffusionRate and then reduces every level by decayRate, clearing
+// entries that fall to zero or below so the field doesn't grow unbounded.
+// It is called once per tick, after every agent has had a chance to deposit.
+func (w *World) DiffuseDecay(diffusionRate, decayRate float64) {
+	next := make(map[Hex]float64, len(w.pheromone))
+
+	for h, level := range w.pheromone {
+		share := level * diffusionRate / 6
+		stay := level - level*diffusionRate
+		next[h] += stay
+		for _, n := range h.Neighbors() {
+			next[n] += share
+		}
+	}
+
+	for h, level := range next {
+		level -= level * decayRate
+		if level > 0 {
+			w.pheromone[h] = level
+		} else {
+			delete(w.pheromone, h)
+		}
+	}
+	for h := range w.pheromone {
+		if _, ok := next[h]; !ok {
+			delete(w.pheromone, h)
+		}
+	}
+}
+
+// Gradient returns the neighbor of h with the highest pheromone level, for an
+// agent to step toward as it climbs the trail. If no neighbor has a higher
+// level than h itself, Gradient returns h unchanged.
+func (w *World) Gradient(h Hex) Hex {
+	best := h
+	bestLevel := w.pheromone[h]
+	for _, n := range h.Neighbors() {
+		if level := w.pheromone[n]; level > bestLevel {
+			best = n
+			bestLevel = level
+		}
+	}
+	return best
+}
@@ -0,0 +1,205 @@
+package agent
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// A Renderer draws a World at a given tick somewhere: a terminal, an image file, and
+// so on.
+type Renderer interface {
+	Render(w *World, tick int) error
+}
+
+// Colorer is implemented by Agents that want a specific color when drawn by an
+// ImageRenderer or AnsiColorRenderer. Agents that don't implement it fall back to
+// DefaultColor, keyed off their glyph.
+type Colorer interface {
+	Color() color.RGBA
+}
+
+// DefaultColor picks a color for an Agent based on its glyph, for Agents that don't
+// implement Colorer.
+func DefaultColor(a Agent) color.RGBA {
+	switch a.String() {
+	case "P":
+		return color.RGBA{220, 50, 50, 255}
+	case "p":
+		return color.RGBA{80, 160, 220, 255}
+	case "*":
+		return color.RGBA{90, 180, 90, 255}
+	case "~":
+		return color.RGBA{220, 160, 255, 255}
+	default:
+		return color.RGBA{128, 128, 128, 255}
+	}
+}
+
+// colorOf returns an Agent's color: from Colorer if it implements it, or from
+// DefaultColor otherwise.
+func colorOf(a Agent) color.RGBA {
+	if c, ok := a.(Colorer); ok {
+		return c.Color()
+	}
+	return DefaultColor(a)
+}
+
+// AsciiRenderer renders a World as plain ASCII text, the simulator's original
+// behavior: one call to World.String() per tick.
+type AsciiRenderer struct {
+	Out io.Writer
+}
+
+// Render writes the World's ASCII grid to the renderer's Out.
+func (r *AsciiRenderer) Render(w *World, tick int) error {
+	_, err := fmt.Fprint(r.Out, w.String())
+	return err
+}
+
+// AnsiColorRenderer renders a World as colored terminal glyphs, using 24-bit ANSI
+// escape codes keyed off of each agent's color.
+type AnsiColorRenderer struct {
+	Out io.Writer
+}
+
+// Render writes the World's grid to the renderer's Out, coloring each occupied hex's
+// glyph with the occupying agent's color.
+func (r *AnsiColorRenderer) Render(w *World, tick int) error {
+	occupied := make(map[Hex]Agent)
+	for _, a := range w.agents {
+		rr, cc := a.Position()
+		occupied[Hex{rr, cc}] = a
+	}
+
+	for row := -w.radius; row <= w.radius; row++ {
+		for col := -w.radius; col <= w.radius; col++ {
+			h := Hex{row, col}
+			if h.Distance(Hex{0, 0}) > w.radius {
+				continue
+			}
+			if a, ok := occupied[h]; ok {
+				c := colorOf(a)
+				fmt.Fprintf(r.Out, "\x1b[38;2;%d;%d;%dm%s\x1b[0m", c.R, c.G, c.B, a.String())
+			} else {
+				fmt.Fprint(r.Out, ".")
+			}
+		}
+		fmt.Fprintln(r.Out)
+	}
+	fmt.Fprintln(r.Out)
+	return nil
+}
+
+// ImageRenderer renders each tick of a World to a raster image: a PNG file per tick
+// when Dir is set, an animated GIF of the whole run when GIFPath is set, or both.
+// Call Close once the run is finished to flush any accumulated GIF to disk.
+type ImageRenderer struct {
+	Dir      string
+	GIFPath  string
+	CellSize int
+
+	frames []*image.Paletted
+	delays []int
+}
+
+func (r *ImageRenderer) cellSize() int {
+	if r.CellSize > 0 {
+		return r.CellSize
+	}
+	return 8
+}
+
+func (r *ImageRenderer) paint(w *World) *image.Paletted {
+	size := r.cellSize()
+	width := (2*w.radius + 1) * size
+	height := width
+
+	palette := color.Palette{color.RGBA{0, 0, 0, 255}}
+	seen := make(map[color.RGBA]bool)
+	for _, a := range w.agents {
+		c := colorOf(a)
+		if !seen[c] {
+			seen[c] = true
+			palette = append(palette, c)
+		}
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, width, height), palette)
+
+	occupied := make(map[Hex]Agent)
+	for _, a := range w.agents {
+		rr, cc := a.Position()
+		occupied[Hex{rr, cc}] = a
+	}
+
+	for row := -w.radius; row <= w.radius; row++ {
+		for col := -w.radius; col <= w.radius; col++ {
+			h := Hex{row, col}
+			if h.Distance(Hex{0, 0}) > w.radius {
+				continue
+			}
+			a, ok := occupied[h]
+			if !ok {
+				continue
+			}
+
+			x, y := h.Cartesian()
+			px := int(x*float64(size)) + width/2
+			py := int(y*float64(size)) + height/2
+			idx := uint8(img.Palette.Index(colorOf(a)))
+			for dy := 0; dy < size; dy++ {
+				for dx := 0; dx < size; dx++ {
+					img.SetColorIndex(px+dx, py+dy, idx)
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// Render paints the World to a PNG at Dir/tick-NNNN.png (if Dir is set) and/or
+// appends a frame to the in-memory GIF (if GIFPath is set).
+func (r *ImageRenderer) Render(w *World, tick int) error {
+	img := r.paint(w)
+
+	if r.Dir != "" {
+		f, err := os.Create(filepath.Join(r.Dir, fmt.Sprintf("tick-%04d.png", tick)))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := png.Encode(f, img); err != nil {
+			return err
+		}
+	}
+
+	if r.GIFPath != "" {
+		r.frames = append(r.frames, img)
+		r.delays = append(r.delays, 10)
+	}
+
+	return nil
+}
+
+// Close writes the accumulated GIF to GIFPath, if it was set. It is a no-op
+// otherwise.
+func (r *ImageRenderer) Close() error {
+	if r.GIFPath == "" {
+		return nil
+	}
+
+	f, err := os.Create(r.GIFPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return gif.EncodeAll(f, &gif.GIF{Image: r.frames, Delay: r.delays})
+}
@@ -0,0 +1,226 @@
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Default rates for the pheromone field's per-tick diffusion and decay, used
+// when a World is constructed with NewWorld.
+const (
+	DefaultDiffusionRate = 0.1
+	DefaultDecayRate     = 0.05
+)
+
+// A World is a hexagonal grid of the given radius populated with Agents.
+type World struct {
+	radius int
+	rng    *rand.Rand
+	agents []Agent
+
+	pheromone     map[Hex]float64
+	diffusionRate float64
+	decayRate     float64
+
+	occupancy map[Hex][]Agent
+
+	neighborhoods map[Hex][]HexDist
+}
+
+// NewWorld creates a new World of the given radius, seeded with a handful of
+// Food, Prey, and Predator agents spread randomly across the grid, with Prey and
+// Predator governed by DefaultPreyParams and DefaultPredatorParams, and its own
+// randomly seeded source of randomness.
+func NewWorld(radius int) *World {
+	return NewWorldWithParams(radius, DefaultPreyParams, DefaultPredatorParams)
+}
+
+// NewWorldWithParams creates a new World of the given radius, seeded with a handful
+// of Food, Prey, and Predator agents spread randomly across the grid, with Prey and
+// Predator governed by the given PreyParams and PredatorParams, and its own randomly
+// seeded source of randomness.
+func NewWorldWithParams(radius int, preyParams PreyParams, predatorParams PredatorParams) *World {
+	return NewWorldWithRand(radius, rand.New(rand.NewSource(rand.Int63())), preyParams, predatorParams)
+}
+
+// NewWorldWithRand creates a new World of the given radius, seeded with a handful of
+// Food, Prey, and Predator agents spread randomly across the grid, with Prey and
+// Predator governed by the given PreyParams and PredatorParams.  Every random choice
+// the World or its agents make, now and as the simulation runs, is drawn from rng, so
+// a run can be made fully reproducible by passing in a rng seeded from a known value.
+func NewWorldWithRand(radius int, rng *rand.Rand, preyParams PreyParams, predatorParams PredatorParams) *World {
+	w := &World{
+		radius:        radius,
+		rng:           rng,
+		pheromone:     make(map[Hex]float64),
+		diffusionRate: DefaultDiffusionRate,
+		decayRate:     DefaultDecayRate,
+		occupancy:     make(map[Hex][]Agent),
+		neighborhoods: make(map[Hex][]HexDist),
+	}
+
+	for i := 0; i < radius*radius; i++ {
+		w.agents = append(w.agents, NewFoodWithEnergy(w, preyParams.EnergyFromFood))
+	}
+	for i := 0; i < radius; i++ {
+		w.agents = append(w.agents, NewPreyWithParams(w, preyParams, predatorParams.EnergyFromPrey))
+	}
+	for i := 0; i < radius/4+1; i++ {
+		w.agents = append(w.agents, NewPredatorWithParams(w, predatorParams))
+	}
+
+	return w
+}
+
+// Random returns a uniformly random Hex within the World.
+func (w *World) Random() Hex {
+	return randomHex(w.rng, w.radius)
+}
+
+// RandomBorder returns a uniformly random Hex on the outer ring of the World.
+func (w *World) RandomBorder() Hex {
+	for {
+		h := Hex{w.rng.Intn(2*w.radius+1) - w.radius, w.rng.Intn(2*w.radius+1) - w.radius}
+		if h.Distance(Hex{0, 0}) == w.radius {
+			return h
+		}
+	}
+}
+
+// Occupy adds an Agent to the occupancy grid at its current hex.
+func (w *World) Occupy(a Agent) {
+	r, c := a.Position()
+	h := Hex{r, c}
+	w.occupancy[h] = append(w.occupancy[h], a)
+}
+
+// At returns the Agents currently occupying a Hex.
+func (w *World) At(h Hex) []Agent {
+	return w.occupancy[h]
+}
+
+// resolveCollisions walks every hex with more than one occupant and delivers each
+// pair of co-located agents to one another's AcceptPredator and AcceptPrey methods.
+func (w *World) resolveCollisions() {
+	for _, agents := range w.occupancy {
+		for i, a := range agents {
+			for j, b := range agents {
+				if i == j {
+					continue
+				}
+				switch other := b.(type) {
+				case *Predator:
+					a.AcceptPredator(other)
+				case *Prey:
+					a.AcceptPrey(other)
+				}
+			}
+		}
+	}
+}
+
+// Update advances the World by one tick: every agent is updated, co-located agents
+// are paired off against one another so predation can take effect, dead agents are
+// removed, and newly spawned agents are added.
+func (w *World) Update() {
+	for _, a := range w.agents {
+		a.Update()
+	}
+
+	w.occupancy = make(map[Hex][]Agent, len(w.occupancy))
+	for _, a := range w.agents {
+		w.Occupy(a)
+	}
+	w.resolveCollisions()
+
+	live := w.agents[:0]
+	var spawned []Agent
+	for _, a := range w.agents {
+		if child := a.Spawn(); child != nil {
+			spawned = append(spawned, child)
+		}
+		if a.Alive() {
+			live = append(live, a)
+		}
+	}
+
+	w.agents = append(live, spawned...)
+
+	w.DiffuseDecay(w.diffusionRate, w.decayRate)
+}
+
+// A HexDist pairs a Hex with its distance from some origin, as returned by
+// World.Neighborhood.
+type HexDist struct {
+	Hex  Hex
+	Dist int
+}
+
+// Neighborhood returns every hex in the World sorted by distance outward from
+// origin, closest first. The sort is computed once per distinct origin and cached,
+// since a Broadcast needs the same ordering on every tick of its expansion.
+func (w *World) Neighborhood(origin Hex) []HexDist {
+	if cached, ok := w.neighborhoods[origin]; ok {
+		return cached
+	}
+
+	var hexes []HexDist
+	for r := -w.radius; r <= w.radius; r++ {
+		for c := -w.radius; c <= w.radius; c++ {
+			h := Hex{r, c}
+			if h.Distance(Hex{0, 0}) > w.radius {
+				continue
+			}
+			hexes = append(hexes, HexDist{h, h.Distance(origin)})
+		}
+	}
+	sort.Slice(hexes, func(i, j int) bool { return hexes[i].Dist < hexes[j].Dist })
+
+	w.neighborhoods[origin] = hexes
+	return hexes
+}
+
+// Population returns the current count of Predators, Prey, and Food in the World.
+// Broadcasts are not counted.
+func (w *World) Population() (predators, prey, food int) {
+	for _, a := range w.agents {
+		switch a.(type) {
+		case *Predator:
+			predators++
+		case *Prey:
+			prey++
+		case *Food:
+			food++
+		}
+	}
+	return
+}
+
+// String renders the World as a grid of agent glyphs, one character per hex,
+// with '.' standing in for an empty hex.
+func (w *World) String() string {
+	occupied := make(map[Hex]Agent)
+	for _, a := range w.agents {
+		r, c := a.Position()
+		occupied[Hex{r, c}] = a
+	}
+
+	var buf bytes.Buffer
+	for r := -w.radius; r <= w.radius; r++ {
+		for c := -w.radius; c <= w.radius; c++ {
+			h := Hex{r, c}
+			if h.Distance(Hex{0, 0}) > w.radius {
+				continue
+			}
+			if a, ok := occupied[h]; ok {
+				fmt.Fprint(&buf, a.String())
+			} else {
+				fmt.Fprint(&buf, ".")
+			}
+		}
+		fmt.Fprintln(&buf)
+	}
+	return buf.String()
+}
@@ -0,0 +1,54 @@
+package agent
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// newTestWorld returns an empty World with no seeded agents, so tests can place
+// exactly the agents they care about.
+func newTestWorld() *World {
+	return &World{
+		radius:        4,
+		rng:           rand.New(rand.NewSource(1)),
+		pheromone:     make(map[Hex]float64),
+		occupancy:     make(map[Hex][]Agent),
+		diffusionRate: DefaultDiffusionRate,
+		decayRate:     DefaultDecayRate,
+	}
+}
+
+func TestPreyDiesWhenSharingHexWithPredator(t *testing.T) {
+	w := newTestWorld()
+	pred := NewPredator(w)
+	prey := NewPrey(w)
+	prey.Hex = pred.Hex
+	// Pin both wander goals to the shared hex so neither agent's own movement
+	// carries it away before collisions are resolved this tick.
+	prey.Mobile.goal.Copy(prey.Hex)
+	pred.Mobile.goal.Copy(pred.Hex)
+	w.agents = []Agent{pred, prey}
+
+	w.Update()
+
+	if prey.Alive() {
+		t.Fatal("expected Prey sharing a hex with a Predator to die")
+	}
+}
+
+func TestFoodDiesWhenSharingHexWithPrey(t *testing.T) {
+	w := newTestWorld()
+	food := NewFood(w)
+	prey := NewPrey(w)
+	prey.Hex = food.Hex
+	// Pin the wander goal to the shared hex so Prey's own movement doesn't carry
+	// it away from Food before collisions are resolved this tick.
+	prey.Mobile.goal.Copy(prey.Hex)
+	w.agents = []Agent{food, prey}
+
+	w.Update()
+
+	if food.Alive() {
+		t.Fatal("expected Food sharing a hex with a Prey to die")
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Joker-Jerome/go/agent"
+	"github.com/Joker-Jerome/go/runner"
+)
+
+var (
+	radius   = flag.Int("radius", 12, "radius of the World's hex grid")
+	headless = flag.Bool("headless", false, "advance the World a fixed number of ticks without interactive stepping")
+	ticks    = flag.Int("ticks", 500, "number of ticks to advance the World")
+	seed     = flag.Int64("seed", 1, "random seed, for reproducible -headless runs")
+	out      = flag.String("out", "", "if set, write per-tick population counts as CSV to this file")
+
+	render   = flag.String("render", "ascii", "how to draw each tick: none, ascii, ansi, or image")
+	imageDir = flag.String("image-dir", "", "with -render image, write a PNG per tick to this directory")
+	gifPath  = flag.String("gif", "", "with -render image, write an animated GIF of the whole run to this path")
+
+	preyInitialEnergy         = flag.Float64("prey-initial-energy", agent.DefaultPreyParams.InitialEnergy, "energy a Prey starts with")
+	preyEnergyCost            = flag.Float64("prey-energy-cost", agent.DefaultPreyParams.EnergyCost, "energy a Prey spends per tick")
+	preyEnergyFromFood        = flag.Float64("prey-energy-from-food", agent.DefaultPreyParams.EnergyFromFood, "energy a Prey gains from eating Food")
+	preyMaxLifespan           = flag.Int("prey-max-lifespan", agent.DefaultPreyParams.MaxLifespan, "ticks a Prey can live before dying of old age")
+	preyReproductiveAge       = flag.Int("prey-reproductive-age", agent.DefaultPreyParams.ReproductiveAge, "age at which a Prey may reproduce")
+	preyReproductionCost      = flag.Float64("prey-reproduction-cost", agent.DefaultPreyParams.ReproductionCost, "energy a Prey spends to reproduce")
+	preyReproductionChance    = flag.Float64("prey-reproduction-chance", agent.DefaultPreyParams.ReproductionChance, "probability per tick that an eligible Prey reproduces")
+
+	predatorInitialEnergy      = flag.Float64("predator-initial-energy", agent.DefaultPredatorParams.InitialEnergy, "energy a Predator starts with")
+	predatorEnergyCost         = flag.Float64("predator-energy-cost", agent.DefaultPredatorParams.EnergyCost, "energy a Predator spends per tick")
+	predatorEnergyFromPrey     = flag.Float64("predator-energy-from-prey", agent.DefaultPredatorParams.EnergyFromPrey, "energy a Predator gains from eating Prey")
+	predatorMaxLifespan        = flag.Int("predator-max-lifespan", agent.DefaultPredatorParams.MaxLifespan, "ticks a Predator can live before dying of old age")
+	predatorReproductiveAge    = flag.Int("predator-reproductive-age", agent.DefaultPredatorParams.ReproductiveAge, "age at which a Predator may reproduce")
+	predatorReproductionCost   = flag.Float64("predator-reproduction-cost", agent.DefaultPredatorParams.ReproductionCost, "energy a Predator spends to reproduce")
+	predatorReproductionChance = flag.Float64("predator-reproduction-chance", agent.DefaultPredatorParams.ReproductionChance, "probability per tick that an eligible Predator reproduces")
+)
+
+// flagExplicitlySet reports whether the named flag was passed on the command line,
+// as opposed to taking its default value.
+func flagExplicitlySet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// newRenderer builds the agent.Renderer selected by -render, writing ASCII and ANSI
+// output to stdout and image output per -image-dir/-gif. A nil Renderer (from
+// "-render none", or the implicit default for a -headless run) draws nothing.
+func newRenderer() (agent.Renderer, error) {
+	mode := *render
+	if mode == "ascii" && *headless && !flagExplicitlySet("render") {
+		mode = "none"
+	}
+
+	switch mode {
+	case "none":
+		return nil, nil
+	case "ascii":
+		return &agent.AsciiRenderer{Out: os.Stdout}, nil
+	case "ansi":
+		return &agent.AnsiColorRenderer{Out: os.Stdout}, nil
+	case "image":
+		return &agent.ImageRenderer{Dir: *imageDir, GIFPath: *gifPath}, nil
+	default:
+		return nil, fmt.Errorf("unknown -render value %q", *render)
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	preyParams := agent.PreyParams{
+		InitialEnergy:      *preyInitialEnergy,
+		EnergyCost:         *preyEnergyCost,
+		EnergyFromFood:     *preyEnergyFromFood,
+		MaxLifespan:        *preyMaxLifespan,
+		ReproductiveAge:    *preyReproductiveAge,
+		ReproductionCost:   *preyReproductionCost,
+		ReproductionChance: *preyReproductionChance,
+	}
+	predatorParams := agent.PredatorParams{
+		InitialEnergy:      *predatorInitialEnergy,
+		EnergyCost:         *predatorEnergyCost,
+		EnergyFromPrey:     *predatorEnergyFromPrey,
+		MaxLifespan:        *predatorMaxLifespan,
+		ReproductiveAge:    *predatorReproductiveAge,
+		ReproductionCost:   *predatorReproductionCost,
+		ReproductionChance: *predatorReproductionChance,
+		BroadcastThreshold: agent.DefaultPredatorParams.BroadcastThreshold,
+		BroadcastSpeed:     agent.DefaultPredatorParams.BroadcastSpeed,
+	}
+
+	r, err := newRenderer()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *headless {
+		stats, err := runner.Run(runner.SimConfig{
+			Radius:         *radius,
+			Ticks:          *ticks,
+			Seed:           *seed,
+			Out:            *out,
+			PreyParams:     preyParams,
+			PredatorParams: predatorParams,
+			Renderer:       r,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%+v\n", stats)
+		return
+	}
+
+	var popLogger *agent.PopulationLogger
+	if *out != "" {
+		var err error
+		popLogger, err = agent.NewPopulationLogger(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer popLogger.Close()
+	}
+
+	w := agent.NewWorldWithParams(*radius, preyParams, predatorParams)
+	in := bufio.NewReader(os.Stdin)
+	for i := 0; i < *ticks; i++ {
+		if r != nil {
+			if err := r.Render(w, i); err != nil {
+				log.Fatal(err)
+			}
+		}
+		w.Update()
+		if popLogger != nil {
+			if err := popLogger.Log(i, w); err != nil {
+				log.Fatal(err)
+			}
+		}
+		fmt.Println()
+
+		in.ReadString('\n')
+	}
+	if r != nil {
+		if err := r.Render(w, *ticks); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if closer, ok := r.(interface{ Close() error }); ok {
+		if err := closer.Close(); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
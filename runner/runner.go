@@ -0,0 +1,85 @@
+// Package runner drives headless, reproducible batch runs of the agent
+// simulation, outside of the interactive stepping loop in cmd/agent.
+package runner
+
+import (
+	"log"
+	"math/rand"
+
+	"github.com/Joker-Jerome/go/agent"
+)
+
+// A SimConfig configures a single headless simulation run: the size of the World,
+// how many ticks to advance it, the random seed to make the run reproducible, and
+// where (if anywhere) to write per-tick population counts.
+type SimConfig struct {
+	Radius int
+	Ticks  int
+	Seed   int64
+	Out    string
+
+	PreyParams     agent.PreyParams
+	PredatorParams agent.PredatorParams
+
+	// Renderer, if set, is called with the World before every tick and once more
+	// after the last one. Leave nil for a pure metrics run with no rendering.
+	Renderer agent.Renderer
+}
+
+// Stats summarizes a completed run's end-of-run population counts.
+type Stats struct {
+	Predators int
+	Prey      int
+	Food      int
+}
+
+// Run constructs a World from cfg, seeded from cfg.Seed for reproducibility, and
+// advances it cfg.Ticks times, writing per-tick population counts to cfg.Out if it
+// is set.  Rendering and tick numbering mirror the interactive loop in cmd/agent:
+// cfg.Renderer is called once before each Update with the tick about to run, plus
+// one final call at cfg.Ticks for the end-of-run state, for cfg.Ticks+1 frames in
+// total.  It returns the end-of-run population counts.
+func Run(cfg SimConfig) (Stats, error) {
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	w := agent.NewWorldWithRand(cfg.Radius, rng, cfg.PreyParams, cfg.PredatorParams)
+
+	var logger *agent.PopulationLogger
+	if cfg.Out != "" {
+		var err error
+		logger, err = agent.NewPopulationLogger(cfg.Out)
+		if err != nil {
+			return Stats{}, err
+		}
+		defer logger.Close()
+	}
+
+	for i := 0; i < cfg.Ticks; i++ {
+		if cfg.Renderer != nil {
+			if err := cfg.Renderer.Render(w, i); err != nil {
+				return Stats{}, err
+			}
+		}
+		w.Update()
+		if logger != nil {
+			if err := logger.Log(i, w); err != nil {
+				return Stats{}, err
+			}
+		}
+	}
+	if cfg.Renderer != nil {
+		if err := cfg.Renderer.Render(w, cfg.Ticks); err != nil {
+			return Stats{}, err
+		}
+	}
+
+	if closer, ok := cfg.Renderer.(interface{ Close() error }); ok {
+		if err := closer.Close(); err != nil {
+			return Stats{}, err
+		}
+	}
+
+	predators, prey, food := w.Population()
+	stats := Stats{predators, prey, food}
+	log.Printf("run complete: %d ticks, final population %+v", cfg.Ticks, stats)
+	return stats, nil
+}